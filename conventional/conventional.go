@@ -0,0 +1,113 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package conventional parses commit messages following the Conventional
+// Commits specification (https://www.conventionalcommits.org) and infers
+// the SemVer bump they imply.
+package conventional
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Bump describes the SemVer level a commit requires.
+type Bump int
+
+const (
+	BumpNone Bump = iota
+	BumpPatch
+	BumpMinor
+	BumpMajor
+)
+
+// Commit is a single parsed Conventional Commit message.
+type Commit struct {
+	Hash     string
+	Header   string
+	Type     string
+	Scope    string
+	Subject  string
+	Body     string
+	Breaking bool
+}
+
+var (
+	headerRegexp         = regexp.MustCompile(`^(\w+)(\(([^)]+)\))?(!)?:\s*(.+)$`)
+	breakingFooterRegexp = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE:\s*(.+)$`)
+)
+
+// Parse extracts the type, scope, subject and breaking-change information
+// from a raw commit message. Messages that do not follow the Conventional
+// Commits format are returned with an empty Type.
+func Parse(hash, message string) Commit {
+	lines := strings.SplitN(message, "\n", 2)
+	header := strings.TrimSpace(lines[0])
+	var body string
+	if len(lines) > 1 {
+		body = lines[1]
+	}
+
+	commit := Commit{
+		Hash:    hash,
+		Header:  header,
+		Subject: header,
+		Body:    body,
+	}
+
+	if matches := headerRegexp.FindStringSubmatch(header); matches != nil {
+		commit.Type = strings.ToLower(matches[1])
+		commit.Scope = matches[3]
+		commit.Breaking = matches[4] == "!"
+		commit.Subject = matches[5]
+	}
+
+	if breakingFooterRegexp.MatchString(body) {
+		commit.Breaking = true
+	}
+
+	return commit
+}
+
+// Bump returns the SemVer level this commit requires.
+func (c Commit) Bump() Bump {
+	if c.Breaking {
+		return BumpMajor
+	}
+	switch c.Type {
+	case "feat":
+		return BumpMinor
+	case "fix", "perf":
+		return BumpPatch
+	default:
+		return BumpNone
+	}
+}
+
+// HighestBump returns the highest Bump required by any of the given commits.
+func HighestBump(commits []Commit) Bump {
+	highest := BumpNone
+	for _, commit := range commits {
+		if b := commit.Bump(); b > highest {
+			highest = b
+		}
+	}
+	return highest
+}
+
+// GroupByType buckets commits by their Conventional Commits type, additionally
+// collecting breaking changes into a "breaking" bucket regardless of type.
+func GroupByType(commits []Commit) map[string][]Commit {
+	groups := map[string][]Commit{}
+	for _, commit := range commits {
+		if commit.Breaking {
+			groups["breaking"] = append(groups["breaking"], commit)
+		}
+		if commit.Type == "" {
+			continue
+		}
+		groups[commit.Type] = append(groups[commit.Type], commit)
+	}
+	return groups
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package conventional
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		message string
+		want    Commit
+	}{
+		{
+			name:    "feat with scope",
+			message: "feat(api): add health endpoint",
+			want:    Commit{Type: "feat", Scope: "api", Subject: "add health endpoint"},
+		},
+		{
+			name:    "fix without scope",
+			message: "fix: handle nil pointer",
+			want:    Commit{Type: "fix", Subject: "handle nil pointer"},
+		},
+		{
+			name:    "bang denotes breaking",
+			message: "feat(api)!: drop v1 endpoints",
+			want:    Commit{Type: "feat", Scope: "api", Subject: "drop v1 endpoints", Breaking: true},
+		},
+		{
+			name:    "breaking change footer",
+			message: "refactor: rename package\n\nBREAKING CHANGE: import path changed",
+			want:    Commit{Type: "refactor", Subject: "rename package", Body: "\nBREAKING CHANGE: import path changed", Breaking: true},
+		},
+		{
+			name:    "non-conventional header",
+			message: "wip stuff",
+			want:    Commit{Subject: "wip stuff"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Parse("abc123", tt.message)
+			if got.Type != tt.want.Type {
+				t.Errorf("Type = %q, want %q", got.Type, tt.want.Type)
+			}
+			if got.Scope != tt.want.Scope {
+				t.Errorf("Scope = %q, want %q", got.Scope, tt.want.Scope)
+			}
+			if got.Subject != tt.want.Subject {
+				t.Errorf("Subject = %q, want %q", got.Subject, tt.want.Subject)
+			}
+			if got.Body != tt.want.Body {
+				t.Errorf("Body = %q, want %q", got.Body, tt.want.Body)
+			}
+			if got.Breaking != tt.want.Breaking {
+				t.Errorf("Breaking = %v, want %v", got.Breaking, tt.want.Breaking)
+			}
+		})
+	}
+}
+
+func TestCommitBump(t *testing.T) {
+	tests := []struct {
+		name   string
+		commit Commit
+		want   Bump
+	}{
+		{name: "breaking always wins", commit: Commit{Type: "fix", Breaking: true}, want: BumpMajor},
+		{name: "feat is minor", commit: Commit{Type: "feat"}, want: BumpMinor},
+		{name: "fix is patch", commit: Commit{Type: "fix"}, want: BumpPatch},
+		{name: "perf is patch", commit: Commit{Type: "perf"}, want: BumpPatch},
+		{name: "chore is none", commit: Commit{Type: "chore"}, want: BumpNone},
+		{name: "unparsed is none", commit: Commit{}, want: BumpNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.commit.Bump(); got != tt.want {
+				t.Errorf("Bump() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHighestBump(t *testing.T) {
+	commits := []Commit{
+		{Type: "chore"},
+		{Type: "fix"},
+		{Type: "feat"},
+	}
+	if got := HighestBump(commits); got != BumpMinor {
+		t.Errorf("HighestBump() = %v, want %v", got, BumpMinor)
+	}
+
+	commits = append(commits, Commit{Type: "feat", Breaking: true})
+	if got := HighestBump(commits); got != BumpMajor {
+		t.Errorf("HighestBump() = %v, want %v", got, BumpMajor)
+	}
+
+	if got := HighestBump(nil); got != BumpNone {
+		t.Errorf("HighestBump(nil) = %v, want %v", got, BumpNone)
+	}
+}
+
+func TestGroupByType(t *testing.T) {
+	feat := Commit{Type: "feat", Subject: "add thing"}
+	fix := Commit{Type: "fix", Subject: "fix thing"}
+	breakingFeat := Commit{Type: "feat", Subject: "drop thing", Breaking: true}
+	breakingFooterOnly := Commit{Subject: "unparsed header", Breaking: true}
+	unparsed := Commit{Subject: "wip"}
+
+	groups := GroupByType([]Commit{feat, fix, breakingFeat, breakingFooterOnly, unparsed})
+
+	if got := groups["feat"]; !reflect.DeepEqual(got, []Commit{feat, breakingFeat}) {
+		t.Errorf("groups[feat] = %v, want %v", got, []Commit{feat, breakingFeat})
+	}
+	if got := groups["fix"]; !reflect.DeepEqual(got, []Commit{fix}) {
+		t.Errorf("groups[fix] = %v, want %v", got, []Commit{fix})
+	}
+	if got := groups["breaking"]; !reflect.DeepEqual(got, []Commit{breakingFeat, breakingFooterOnly}) {
+		t.Errorf("groups[breaking] = %v, want %v", got, []Commit{breakingFeat, breakingFooterOnly})
+	}
+	if _, ok := groups[""]; ok {
+		t.Errorf("unparsed commit must not create an empty-type bucket")
+	}
+}
@@ -0,0 +1,672 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package bumper implements the core git-version-bumper engine: inferring
+// the next SemVer version from Conventional Commits, rendering the
+// changelog and creating the release commit and tag. The pkg/cli package
+// wires this engine into the command line.
+package bumper
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bborbe/git-version-bumper/conventional"
+	"github.com/bborbe/git-version-bumper/pkg/forge"
+	"github.com/bborbe/git-version-bumper/pkg/lint"
+	"github.com/bborbe/git-version-bumper/pkg/render"
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/config"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+	"gopkg.in/src-d/go-git.v4/plumbing/transport"
+	githttp "gopkg.in/src-d/go-git.v4/plumbing/transport/http"
+	gitssh "gopkg.in/src-d/go-git.v4/plumbing/transport/ssh"
+)
+
+// DefaultChangelogFilename is the name CHANGELOG.md is scaffolded and
+// looked up under when no other path is configured.
+const DefaultChangelogFilename = "CHANGELOG.md"
+
+// DefaultChangelog is the content a new repository is scaffolded with.
+const DefaultChangelog = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+Please choose versions by [Semantic Versioning](http://semver.org/).
+
+* MAJOR version when you make incompatible API changes,
+* MINOR version when you add functionality in a backwards-compatible manner, and
+* PATCH version when you make backwards-compatible bug fixes.
+
+## 1.0.0
+
+- Initial Version
+`
+
+var changelogHeadRegexp = regexp.MustCompile(`(?s)^(.*?)(\n##\s.*)$`)
+
+// Bumper is the git-version-bumper engine for a single repository.
+type Bumper struct {
+	Repo string
+}
+
+// New returns a Bumper operating on the git repository at repo.
+func New(repo string) *Bumper {
+	return &Bumper{Repo: repo}
+}
+
+// NextResult is the outcome of computing the next version: the version
+// itself, the previous version it was derived from and the commits that
+// were scanned to infer it.
+type NextResult struct {
+	Version         Version
+	PreviousVersion Version
+	Commits         []conventional.Commit
+}
+
+// Commits returns the Conventional Commits reachable from to, stopping
+// before from. An empty from defaults to the latest semver tag; an empty
+// to defaults to HEAD.
+func (b *Bumper) Commits(from, to string) ([]conventional.Commit, error) {
+	r, err := git.PlainOpen(b.Repo)
+	if err != nil {
+		return nil, errors.Wrap(err, "open git directory failed")
+	}
+
+	fromHash, err := b.fromHash(r, from)
+	if err != nil {
+		return nil, err
+	}
+	toHash, err := resolveRevision(r, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolve --to failed")
+	}
+
+	return commitsBetween(r, fromHash, toHash)
+}
+
+// LatestVersion returns the highest semver tag in the repository, or the
+// zero Version if none exists yet.
+func (b *Bumper) LatestVersion() (Version, error) {
+	r, err := git.PlainOpen(b.Repo)
+	if err != nil {
+		return Version{}, errors.Wrap(err, "open git directory failed")
+	}
+	version, _, err := latestVersionTag(r)
+	return version, errors.Wrap(err, "find latest version tag failed")
+}
+
+func (b *Bumper) fromHash(r *git.Repository, from string) (plumbing.Hash, error) {
+	if from != "" {
+		hash, err := resolveRevision(r, from)
+		return hash, errors.Wrap(err, "resolve --from failed")
+	}
+	_, hash, err := latestVersionTag(r)
+	return hash, errors.Wrap(err, "find latest version tag failed")
+}
+
+// Next computes the next version and the commits it was inferred from,
+// without changing anything on disk or in git. bump selects the SemVer
+// level to apply (auto|major|minor|patch); from/to override the default
+// commit range (latest semver tag..HEAD).
+func (b *Bumper) Next(bump, from, to string) (*NextResult, error) {
+	r, err := git.PlainOpen(b.Repo)
+	if err != nil {
+		return nil, errors.Wrap(err, "open git directory failed")
+	}
+
+	previous, _, err := latestVersionTag(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "find latest version tag failed")
+	}
+	fromHash, err := b.fromHash(r, from)
+	if err != nil {
+		return nil, err
+	}
+	toHash, err := resolveRevision(r, to)
+	if err != nil {
+		return nil, errors.Wrap(err, "resolve --to failed")
+	}
+
+	commits, err := commitsBetween(r, fromHash, toHash)
+	if err != nil {
+		return nil, errors.Wrap(err, "collect commits failed")
+	}
+
+	level, err := parseBump(bump, commits)
+	if err != nil {
+		return nil, err
+	}
+	if level == conventional.BumpNone {
+		return nil, errors.New("no commits found that require a version bump")
+	}
+
+	return &NextResult{
+		Version:         previous.Bump(level),
+		PreviousVersion: previous,
+		Commits:         commits,
+	}, nil
+}
+
+// ChangelogOptions configures how a release's changelog entry is rendered.
+type ChangelogOptions struct {
+	// Format selects one of the embedded default templates (md|json) and
+	// is ignored when TemplateFile is set.
+	Format string
+	// TemplateFile, when set, overrides Format with a user-supplied
+	// text/template file.
+	TemplateFile string
+	// Message is used by the default templates as a fallback entry when
+	// no commit falls into a changelog section (breaking, feat, fix or
+	// perf), e.g. when there are no commits in the scanned range.
+	Message string
+}
+
+// WriteChangelog renders the entry for version/commits and inserts it into
+// the changelog file at path, creating the file from DefaultChangelog if it
+// does not exist yet. The Markdown splice below assumes a "## " heading per
+// release, so opts.Format=json is printed to stdout instead: it has no
+// Markdown heading to splice against and is meant for downstream tooling to
+// consume directly, not for CHANGELOG.md.
+func (b *Bumper) WriteChangelog(path string, version, previous Version, commits []conventional.Commit, opts ChangelogOptions) error {
+	section, err := renderChangelogEntry(version, previous, commits, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.TemplateFile == "" && opts.Format == "json" {
+		_, err := fmt.Print(section)
+		return err
+	}
+
+	changelog, err := ioutil.ReadFile(path)
+	if err != nil {
+		changelog = []byte(DefaultChangelog)
+	}
+
+	changelog, err = addChangelogSection(changelog, section)
+	if err != nil {
+		return errors.Wrap(err, "update changelog failed")
+	}
+
+	if err := ioutil.WriteFile(path, changelog, 0600); err != nil {
+		return errors.Wrap(err, "write changelog failed")
+	}
+	return nil
+}
+
+// renderChangelogEntry renders the entry for version/commits without
+// touching the changelog file, so it can be reused for publishing release
+// notes to a forge.
+func renderChangelogEntry(version, previous Version, commits []conventional.Commit, opts ChangelogOptions) (string, error) {
+	renderer, err := render.New(opts.Format, opts.TemplateFile)
+	if err != nil {
+		return "", err
+	}
+
+	section, err := renderer.Render(render.Data{
+		Version:         version.String(),
+		PreviousVersion: previous.String(),
+		Date:            time.Now(),
+		Sections:        changelogSections(commits),
+		Commits:         commits,
+		Message:         opts.Message,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "render changelog entry failed")
+	}
+	return section, nil
+}
+
+// ReleaseOptions configures Release in addition to the changelog rendering
+// options it embeds.
+type ReleaseOptions struct {
+	ChangelogOptions
+	ChangelogPath string
+	AuthorName    string
+	AuthorEmail   string
+	// LintRules is applied to commits as a pre-flight check; the release
+	// is aborted if any commit violates them. The zero value disables
+	// every check.
+	LintRules lint.Rules
+	// SignKey, when set, GPG-signs the release commit and tag.
+	SignKey *openpgp.Entity
+	// Push, when set, pushes the release commit and tag to Remote after
+	// tagging.
+	Push bool
+	// Remote is the git remote to push to, defaults to "origin".
+	Remote string
+	// Token authenticates the push over HTTP(S); ignored for SSH remotes,
+	// which authenticate via the local SSH agent instead.
+	Token string
+	// Publisher, when set, publishes the changelog entry as the release
+	// notes for version on RepoSlug.
+	Publisher forge.ReleasePublisher
+	// RepoSlug identifies the repository on the forge, e.g.
+	// "bborbe/git-version-bumper".
+	RepoSlug string
+}
+
+// Release lints commits, writes the changelog entry for version/commits,
+// then commits and tags the repository. If opts.Push is set, the commit and
+// tag are pushed to opts.Remote; if opts.Publisher is set, the changelog
+// entry is published as the release notes for version.
+func (b *Bumper) Release(ctx context.Context, version, previous Version, commits []conventional.Commit, opts ReleaseOptions) error {
+	if violations := lint.CheckAll(commits, opts.LintRules); len(violations) > 0 {
+		return errors.Errorf("release aborted, %d commit(s) failed lint: %v", len(violations), violations)
+	}
+	if opts.TemplateFile == "" && opts.Format == "json" {
+		return errors.New("format json has no changelog file to commit, use the changelog command or supply --template-file")
+	}
+
+	r, err := git.PlainOpen(b.Repo)
+	if err != nil {
+		return errors.Wrap(err, "open git directory failed")
+	}
+
+	w, err := r.Worktree()
+	if err != nil {
+		return errors.Wrap(err, "get worktree failed")
+	}
+
+	if _, err := r.Tag(version.String()); err == nil {
+		return errors.New("tag already exists")
+	}
+
+	if err := b.WriteChangelog(opts.ChangelogPath, version, previous, commits, opts.ChangelogOptions); err != nil {
+		return err
+	}
+
+	// Worktree.Add expects a path relative to the worktree root, not the
+	// absolute filesystem path WriteChangelog just wrote.
+	relChangelogPath, err := filepath.Rel(b.Repo, opts.ChangelogPath)
+	if err != nil {
+		return errors.Wrap(err, "resolve changelog path relative to repo failed")
+	}
+	if _, err := w.Add(relChangelogPath); err != nil {
+		return errors.Wrap(err, "add file failed")
+	}
+
+	signature := &object.Signature{
+		Name:  opts.AuthorName,
+		Email: opts.AuthorEmail,
+		When:  time.Now(),
+	}
+	commit, err := w.Commit(opts.Message, &git.CommitOptions{
+		All:     true,
+		Author:  signature,
+		SignKey: opts.SignKey,
+	})
+	if err != nil {
+		return errors.Wrap(err, "commit failed")
+	}
+
+	obj, err := r.CommitObject(commit)
+	if err != nil {
+		return errors.Wrap(err, "commit failed")
+	}
+
+	_, err = r.CreateTag(version.String(), obj.Hash, &git.CreateTagOptions{
+		Tagger:  signature,
+		Message: version.String(),
+		SignKey: opts.SignKey,
+	})
+	if err != nil {
+		return errors.Wrap(err, "create tag failed")
+	}
+
+	if opts.Push {
+		if err := push(r, opts.Remote, version, opts.Token); err != nil {
+			return err
+		}
+	}
+
+	if opts.Publisher != nil {
+		entry, err := renderChangelogEntry(version, previous, commits, opts.ChangelogOptions)
+		if err != nil {
+			return err
+		}
+		if err := opts.Publisher.Publish(ctx, opts.RepoSlug, version.String(), entry); err != nil {
+			return errors.Wrap(err, "publish release failed")
+		}
+	}
+
+	return nil
+}
+
+// push pushes the current branch and the tag for version to remote.
+func push(r *git.Repository, remote string, version Version, token string) error {
+	if remote == "" {
+		remote = "origin"
+	}
+
+	cfg, err := r.Remote(remote)
+	if err != nil {
+		return errors.Wrapf(err, "get remote %q failed", remote)
+	}
+
+	auth, err := pushAuth(cfg, token)
+	if err != nil {
+		return err
+	}
+
+	head, err := r.Head()
+	if err != nil {
+		return errors.Wrap(err, "get head failed")
+	}
+
+	tagRefSpec := config.RefSpec(fmt.Sprintf("refs/tags/%s:refs/tags/%s", version.String(), version.String()))
+	branchRefSpec := config.RefSpec(fmt.Sprintf("%s:%s", head.Name(), head.Name()))
+
+	err = r.Push(&git.PushOptions{
+		RemoteName: remote,
+		RefSpecs:   []config.RefSpec{branchRefSpec, tagRefSpec},
+		Auth:       auth,
+	})
+	if err != nil && err != git.NoErrAlreadyUpToDate {
+		return errors.Wrap(err, "push failed")
+	}
+	return nil
+}
+
+// pushAuth derives the transport.AuthMethod for remote from its configured
+// URL: HTTP(S) remotes authenticate with token as an OAuth-style basic auth
+// password, SSH remotes authenticate via the local SSH agent.
+func pushAuth(remote *git.Remote, token string) (transport.AuthMethod, error) {
+	urls := remote.Config().URLs
+	if len(urls) == 0 {
+		return nil, errors.New("remote has no url")
+	}
+
+	if strings.HasPrefix(urls[0], "http://") || strings.HasPrefix(urls[0], "https://") {
+		return &githttp.BasicAuth{
+			Username: "git",
+			Password: token,
+		}, nil
+	}
+
+	auth, err := gitssh.NewSSHAgentAuth("git")
+	if err != nil {
+		return nil, errors.Wrap(err, "create ssh agent auth failed")
+	}
+	return auth, nil
+}
+
+func parseBump(bump string, commits []conventional.Commit) (conventional.Bump, error) {
+	switch bump {
+	case "", "auto":
+		return conventional.HighestBump(commits), nil
+	case "major":
+		return conventional.BumpMajor, nil
+	case "minor":
+		return conventional.BumpMinor, nil
+	case "patch":
+		return conventional.BumpPatch, nil
+	default:
+		return conventional.BumpNone, errors.Errorf("unknown bump %q", bump)
+	}
+}
+
+// latestVersionTag returns the highest semver tag in r and the hash of the
+// commit it points to. If no semver tag exists, it returns the zero Version
+// and the zero hash so the caller scans the whole history.
+func latestVersionTag(r *git.Repository) (Version, plumbing.Hash, error) {
+	tags, err := r.Tags()
+	if err != nil {
+		return Version{}, plumbing.ZeroHash, errors.Wrap(err, "list tags failed")
+	}
+	defer tags.Close()
+
+	var (
+		latest Version
+		hash   plumbing.Hash
+		found  bool
+	)
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		name := strings.TrimPrefix(ref.Name().Short(), "v")
+		version, err := ParseVersion(name)
+		if err != nil {
+			return nil
+		}
+		if found && version.Compare(latest) <= 0 {
+			return nil
+		}
+		commitHash, err := r.ResolveRevision(plumbing.Revision(ref.Name().Short()))
+		if err != nil {
+			return err
+		}
+		latest = *version
+		hash = *commitHash
+		found = true
+		return nil
+	})
+	if err != nil {
+		return Version{}, plumbing.ZeroHash, err
+	}
+	return latest, hash, nil
+}
+
+func resolveRevision(r *git.Repository, rev string) (plumbing.Hash, error) {
+	if rev == "" {
+		head, err := r.Head()
+		if err != nil {
+			return plumbing.ZeroHash, errors.Wrap(err, "get HEAD failed")
+		}
+		return head.Hash(), nil
+	}
+	hash, err := r.ResolveRevision(plumbing.Revision(rev))
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return *hash, nil
+}
+
+// commitsBetween returns the commits reachable from to, stopping before
+// from, ordered from newest to oldest.
+func commitsBetween(r *git.Repository, from, to plumbing.Hash) ([]conventional.Commit, error) {
+	iter, err := r.Log(&git.LogOptions{From: to})
+	if err != nil {
+		return nil, errors.Wrap(err, "log failed")
+	}
+	defer iter.Close()
+
+	var commits []conventional.Commit
+	err = iter.ForEach(func(c *object.Commit) error {
+		if c.Hash == from {
+			return storer.ErrStop
+		}
+		commits = append(commits, conventional.Parse(c.Hash.String(), c.Message))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return commits, nil
+}
+
+// versionRegexp matches a SemVer 2.0.0 version, e.g. "1.2.3", "1.2.3-beta.1"
+// or "2.0.0+incompatible" (the Go modules convention for major-version-2+
+// tags without a /vN module path suffix).
+var versionRegexp = regexp.MustCompile(`^(\d+)\.(\d+)\.(\d+)(?:-([0-9A-Za-z-.]+))?(?:\+([0-9A-Za-z-.]+))?$`)
+
+// ParseVersion parses a "1.2.3", "1.2.3-beta.1" or "1.2.3-beta.1+build.5"
+// string into a Version.
+func ParseVersion(versionNumber string) (*Version, error) {
+	matches := versionRegexp.FindStringSubmatch(versionNumber)
+	if matches == nil {
+		return nil, errors.Errorf("expect version in format 1.2.3[-prerelease][+build], got %q", versionNumber)
+	}
+	var err error
+	version := &Version{}
+	version.Major, err = strconv.Atoi(matches[1])
+	if err != nil {
+		return nil, err
+	}
+	version.Minor, err = strconv.Atoi(matches[2])
+	if err != nil {
+		return nil, err
+	}
+	version.Patch, err = strconv.Atoi(matches[3])
+	if err != nil {
+		return nil, err
+	}
+	version.PreRelease = matches[4]
+	version.BuildMetadata = matches[5]
+	return version, nil
+}
+
+// Version is a parsed SemVer 2.0.0 version.
+type Version struct {
+	Major         int
+	Minor         int
+	Patch         int
+	PreRelease    string
+	BuildMetadata string
+}
+
+func (v Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.PreRelease != "" {
+		s += "-" + v.PreRelease
+	}
+	if v.BuildMetadata != "" {
+		s += "+" + v.BuildMetadata
+	}
+	return s
+}
+
+// Bump returns the next Version after applying bump to v. The result never
+// carries over PreRelease or BuildMetadata from v.
+func (v Version) Bump(bump conventional.Bump) Version {
+	switch bump {
+	case conventional.BumpMajor:
+		return Version{Major: v.Major + 1}
+	case conventional.BumpMinor:
+		return Version{Major: v.Major, Minor: v.Minor + 1}
+	case conventional.BumpPatch:
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch + 1}
+	default:
+		return Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	}
+}
+
+// Compare returns -1, 0 or 1 if v is less than, equal to, or greater than
+// other, following SemVer 2.0.0 precedence rules: numeric identifiers
+// compare numerically, alphanumeric identifiers compare lexically, a
+// pre-release version has lower precedence than the associated normal
+// version, and build metadata is ignored.
+func (v Version) Compare(other Version) int {
+	if v.Major != other.Major {
+		return compareInt(v.Major, other.Major)
+	}
+	if v.Minor != other.Minor {
+		return compareInt(v.Minor, other.Minor)
+	}
+	if v.Patch != other.Patch {
+		return compareInt(v.Patch, other.Patch)
+	}
+	return comparePreRelease(v.PreRelease, other.PreRelease)
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// comparePreRelease implements the SemVer precedence rules for pre-release
+// identifiers: a version without a pre-release has higher precedence than
+// one with, dot-separated identifiers are compared in turn, and a larger
+// set of identifiers has higher precedence when all prior ones are equal.
+func comparePreRelease(a, b string) int {
+	if a == b {
+		return 0
+	}
+	if a == "" {
+		return 1
+	}
+	if b == "" {
+		return -1
+	}
+
+	aParts := strings.Split(a, ".")
+	bParts := strings.Split(b, ".")
+	for i := 0; i < len(aParts) && i < len(bParts); i++ {
+		if c := comparePreReleaseIdentifier(aParts[i], bParts[i]); c != 0 {
+			return c
+		}
+	}
+	return compareInt(len(aParts), len(bParts))
+}
+
+func comparePreReleaseIdentifier(a, b string) int {
+	aNum, aIsNum := asUint(a)
+	bNum, bIsNum := asUint(b)
+	switch {
+	case aIsNum && bIsNum:
+		return compareInt(int(aNum), int(bNum))
+	case aIsNum:
+		return -1
+	case bIsNum:
+		return 1
+	default:
+		return strings.Compare(a, b)
+	}
+}
+
+func asUint(s string) (uint64, bool) {
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// changelogSections groups commits into the Features / Bug Fixes /
+// BREAKING CHANGES sections the default templates render.
+func changelogSections(commits []conventional.Commit) []render.Section {
+	groups := conventional.GroupByType(commits)
+
+	var sections []render.Section
+	if breaking := groups["breaking"]; len(breaking) > 0 {
+		sections = append(sections, render.Section{Name: "breaking", Title: "BREAKING CHANGES", Commits: breaking})
+	}
+	if feat := groups["feat"]; len(feat) > 0 {
+		sections = append(sections, render.Section{Name: "feat", Title: "Features", Commits: feat})
+	}
+	fixes := append(append([]conventional.Commit{}, groups["fix"]...), groups["perf"]...)
+	if len(fixes) > 0 {
+		sections = append(sections, render.Section{Name: "fix", Title: "Bug Fixes", Commits: fixes})
+	}
+	return sections
+}
+
+// addChangelogSection inserts the rendered section into changelog, right
+// above the most recent existing entry.
+func addChangelogSection(changelog []byte, section string) ([]byte, error) {
+	matches := changelogHeadRegexp.FindSubmatch(changelog)
+	if matches == nil {
+		return nil, errors.New("unexpected CHANGELOG.md format")
+	}
+	var buf strings.Builder
+	buf.Write(matches[1])
+	buf.WriteString(section)
+	buf.Write(matches[2])
+	return []byte(buf.String()), nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bumper
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+// TestRelease exercises Release end-to-end against a real on-disk repo, the
+// same way the release command drives it: ChangelogPath is an absolute path
+// built via filepath.Join(repo, ...), mirroring pkg/cli/release.go.
+func TestRelease(t *testing.T) {
+	dir, err := ioutil.TempDir("", "git-version-bumper-release-test")
+	if err != nil {
+		t.Fatalf("TempDir failed: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	r, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("PlainInit failed: %v", err)
+	}
+	w, err := r.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree failed: %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "README.md"), []byte("hello\n"), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	if _, err := w.Add("README.md"); err != nil {
+		t.Fatalf("Add failed: %v", err)
+	}
+	signature := &object.Signature{Name: "Test", Email: "test@example.com"}
+	if _, err := w.Commit("feat: initial commit", &git.CommitOptions{All: true, Author: signature}); err != nil {
+		t.Fatalf("Commit failed: %v", err)
+	}
+
+	b := New(dir)
+	changelogPath := filepath.Join(dir, DefaultChangelogFilename)
+	err = b.Release(context.Background(), Version{Major: 1}, Version{}, nil, ReleaseOptions{
+		ChangelogPath: changelogPath,
+		AuthorName:    "Test",
+		AuthorEmail:   "test@example.com",
+		ChangelogOptions: ChangelogOptions{
+			Message: "release 1.0.0",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Release failed: %v", err)
+	}
+
+	if _, err := os.Stat(changelogPath); err != nil {
+		t.Fatalf("changelog was not written: %v", err)
+	}
+
+	if _, err := r.Tag("1.0.0"); err != nil {
+		t.Fatalf("tag 1.0.0 was not created: %v", err)
+	}
+
+	head, err := r.Head()
+	if err != nil {
+		t.Fatalf("Head failed: %v", err)
+	}
+	commit, err := r.CommitObject(head.Hash())
+	if err != nil {
+		t.Fatalf("CommitObject failed: %v", err)
+	}
+	stats, err := commit.Stats()
+	if err != nil {
+		t.Fatalf("Stats failed: %v", err)
+	}
+	found := false
+	for _, s := range stats {
+		if s.Name == DefaultChangelogFilename {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("release commit did not include %s, stats: %+v", DefaultChangelogFilename, stats)
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bumper
+
+import (
+	"testing"
+
+	"github.com/bborbe/git-version-bumper/conventional"
+)
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Version
+		wantErr bool
+	}{
+		{name: "plain", input: "1.2.3", want: Version{Major: 1, Minor: 2, Patch: 3}},
+		{
+			name:  "pre-release",
+			input: "1.2.3-beta.1",
+			want:  Version{Major: 1, Minor: 2, Patch: 3, PreRelease: "beta.1"},
+		},
+		{
+			name:  "pre-release and build metadata",
+			input: "1.2.3-beta.1+exp.sha.5114f85",
+			want:  Version{Major: 1, Minor: 2, Patch: 3, PreRelease: "beta.1", BuildMetadata: "exp.sha.5114f85"},
+		},
+		{
+			name:  "incompatible build metadata",
+			input: "2.0.0+incompatible",
+			want:  Version{Major: 2, Minor: 0, Patch: 0, BuildMetadata: "incompatible"},
+		},
+		{name: "missing patch", input: "1.2", wantErr: true},
+		{name: "leading v", input: "v1.2.3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseVersion(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseVersion(%q) expected error, got none", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseVersion(%q) unexpected error: %v", tt.input, err)
+			}
+			if *got != tt.want {
+				t.Errorf("ParseVersion(%q) = %+v, want %+v", tt.input, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionString(t *testing.T) {
+	v := Version{Major: 1, Minor: 2, Patch: 3, PreRelease: "rc.1", BuildMetadata: "build.42"}
+	if got, want := v.String(), "1.2.3-rc.1+build.42"; got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+}
+
+func TestVersionBump(t *testing.T) {
+	v := Version{Major: 1, Minor: 2, Patch: 3, PreRelease: "rc.1", BuildMetadata: "build.42"}
+
+	tests := []struct {
+		name string
+		bump conventional.Bump
+		want Version
+	}{
+		{name: "major drops pre-release", bump: conventional.BumpMajor, want: Version{Major: 2}},
+		{name: "minor resets patch", bump: conventional.BumpMinor, want: Version{Major: 1, Minor: 3}},
+		{name: "patch increments patch", bump: conventional.BumpPatch, want: Version{Major: 1, Minor: 2, Patch: 4}},
+		{name: "none keeps normal version", bump: conventional.BumpNone, want: Version{Major: 1, Minor: 2, Patch: 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := v.Bump(tt.bump); got != tt.want {
+				t.Errorf("Bump(%v) = %+v, want %+v", tt.bump, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestVersionCompare(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want int
+	}{
+		{name: "equal", a: "1.2.3", b: "1.2.3", want: 0},
+		{name: "major wins", a: "2.0.0", b: "1.9.9", want: 1},
+		{name: "minor wins", a: "1.3.0", b: "1.2.9", want: 1},
+		{name: "patch wins", a: "1.2.4", b: "1.2.3", want: 1},
+		{name: "pre-release has lower precedence than normal", a: "1.0.0-alpha", b: "1.0.0", want: -1},
+		{name: "numeric identifiers compare numerically", a: "1.0.0-alpha.2", b: "1.0.0-alpha.10", want: -1},
+		{name: "alphanumeric identifiers compare lexically", a: "1.0.0-alpha", b: "1.0.0-beta", want: -1},
+		{name: "numeric identifiers have lower precedence than alphanumeric", a: "1.0.0-alpha.1", b: "1.0.0-alpha.beta", want: -1},
+		{name: "more fields has higher precedence", a: "1.0.0-alpha.1", b: "1.0.0-alpha", want: 1},
+		{name: "build metadata ignored", a: "1.2.3+build.1", b: "1.2.3+build.2", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := ParseVersion(tt.a)
+			if err != nil {
+				t.Fatalf("ParseVersion(%q) failed: %v", tt.a, err)
+			}
+			b, err := ParseVersion(tt.b)
+			if err != nil {
+				t.Fatalf("ParseVersion(%q) failed: %v", tt.b, err)
+			}
+			if got := a.Compare(*b); got != tt.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+			}
+			if got := b.Compare(*a); got != -tt.want {
+				t.Errorf("Compare(%q, %q) = %d, want %d", tt.b, tt.a, got, -tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli
+
+import (
+	"path/filepath"
+
+	"github.com/bborbe/git-version-bumper/pkg/bumper"
+	"github.com/urfave/cli"
+)
+
+var changelogCommand = cli.Command{
+	Name:  "changelog",
+	Usage: "regenerate CHANGELOG.md between two refs without tagging",
+	Flags: []cli.Flag{
+		cli.StringFlag{Name: "bump", Value: "auto", Usage: "auto|major|minor|patch", EnvVar: "BUMP"},
+		cli.StringFlag{Name: "from", Usage: "revision to scan from, defaults to the latest semver tag", EnvVar: "FROM"},
+		cli.StringFlag{Name: "to", Usage: "revision to scan to, defaults to HEAD", EnvVar: "TO"},
+		cli.StringFlag{Name: "message", Usage: "fallback changelog entry when no commits are scanned", EnvVar: "MESSAGE"},
+		cli.StringFlag{Name: "changelog", Usage: "path to the changelog file, relative to repo", EnvVar: "CHANGELOG"},
+		cli.StringFlag{Name: "format", Value: "md", Usage: "md|json, ignored when --template-file is set; json is printed to stdout instead of written to the changelog file", EnvVar: "FORMAT"},
+		cli.StringFlag{Name: "template-file", Usage: "path to a custom text/template changelog template", EnvVar: "TEMPLATE_FILE"},
+		cli.StringFlag{Name: "pre-release", Usage: "pre-release identifier to append, e.g. rc.1", EnvVar: "PRE_RELEASE"},
+		cli.StringFlag{Name: "release-meta", Usage: "build metadata to append, e.g. build.42", EnvVar: "RELEASE_META"},
+	},
+	Action: func(c *cli.Context) error {
+		repo, err := repoPath(c)
+		if err != nil {
+			return err
+		}
+		cfg, err := loadConfig(c, repo)
+		if err != nil {
+			return err
+		}
+
+		b := bumper.New(repo)
+		next, err := b.Next(c.String("bump"), c.String("from"), c.String("to"))
+		if err != nil {
+			return err
+		}
+		version := applyPreRelease(next.Version, c.String("pre-release"), c.String("release-meta"))
+
+		changelogPath := firstNonEmpty(c.String("changelog"), cfg.ChangelogPath)
+		return b.WriteChangelog(filepath.Join(repo, changelogPath), version, next.PreviousVersion, next.Commits, bumper.ChangelogOptions{
+			Format:       c.String("format"),
+			TemplateFile: firstNonEmpty(c.String("template-file"), cfg.Template),
+			Message:      c.String("message"),
+		})
+	},
+}
@@ -0,0 +1,81 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package cli wires the git-version-bumper engine (pkg/bumper) and
+// configuration (pkg/config) into a urfave/cli application exposing the
+// init, next, changelog, release and lint subcommands.
+package cli
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/bborbe/git-version-bumper/pkg/bumper"
+	"github.com/bborbe/git-version-bumper/pkg/config"
+	"github.com/urfave/cli"
+)
+
+// New returns the git-version-bumper application.
+func New() *cli.App {
+	app := cli.NewApp()
+	app.Name = "git-version-bumper"
+	app.Usage = "bump the version of a git repository based on its commit history"
+	app.Flags = []cli.Flag{
+		cli.StringFlag{Name: "repo", Usage: "path to the git repository", Value: ".", EnvVar: "REPO"},
+		cli.StringFlag{Name: "config", Usage: "path to the config file, relative to repo", Value: config.Filename, EnvVar: "CONFIG"},
+	}
+	app.Commands = []cli.Command{
+		initCommand,
+		nextCommand,
+		changelogCommand,
+		releaseCommand,
+		lintCommand,
+	}
+	return app
+}
+
+// repoPath resolves the --repo flag to an absolute path.
+func repoPath(c *cli.Context) (string, error) {
+	repo := c.GlobalString("repo")
+	if repo == "" || repo == "." {
+		return os.Getwd()
+	}
+	return filepath.Abs(repo)
+}
+
+// loadConfig reads the config file for repo and applies it as defaults,
+// ready to be overridden by command-specific flags.
+func loadConfig(c *cli.Context, repo string) (config.Config, error) {
+	path := c.GlobalString("config")
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(repo, path)
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		return config.Config{}, err
+	}
+	return cfg.WithDefaults(), nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// applyPreRelease overrides version's PreRelease/BuildMetadata with
+// preRelease/buildMetadata when they are set, so --pre-release and
+// --release-meta work regardless of how version was computed.
+func applyPreRelease(version bumper.Version, preRelease, buildMetadata string) bumper.Version {
+	if preRelease != "" {
+		version.PreRelease = preRelease
+	}
+	if buildMetadata != "" {
+		version.BuildMetadata = buildMetadata
+	}
+	return version
+}
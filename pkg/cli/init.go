@@ -0,0 +1,57 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/bborbe/git-version-bumper/pkg/bumper"
+	"github.com/bborbe/git-version-bumper/pkg/config"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	yaml "gopkg.in/yaml.v2"
+)
+
+var initCommand = cli.Command{
+	Name:  "init",
+	Usage: "scaffold CHANGELOG.md and .gitversionbumper.yaml",
+	Flags: []cli.Flag{
+		cli.StringFlag{Name: "git-author-name", Usage: "Author Name", EnvVar: "GIT_AUTHOR_NAME"},
+		cli.StringFlag{Name: "git-author-email", Usage: "Author Email", EnvVar: "GIT_AUTHOR_EMAIL"},
+	},
+	Action: func(c *cli.Context) error {
+		repo, err := repoPath(c)
+		if err != nil {
+			return err
+		}
+
+		changelogPath := filepath.Join(repo, bumper.DefaultChangelogFilename)
+		if _, err := os.Stat(changelogPath); os.IsNotExist(err) {
+			if err := ioutil.WriteFile(changelogPath, []byte(bumper.DefaultChangelog), 0600); err != nil {
+				return errors.Wrap(err, "write CHANGELOG.md failed")
+			}
+		}
+
+		configPath := filepath.Join(repo, config.Filename)
+		if _, err := os.Stat(configPath); os.IsNotExist(err) {
+			cfg := config.Config{
+				AuthorName:    c.String("git-author-name"),
+				AuthorEmail:   c.String("git-author-email"),
+				ChangelogPath: bumper.DefaultChangelogFilename,
+			}
+			content, err := yaml.Marshal(cfg)
+			if err != nil {
+				return errors.Wrap(err, "marshal config failed")
+			}
+			if err := ioutil.WriteFile(configPath, content, 0600); err != nil {
+				return errors.Wrap(err, "write config failed")
+			}
+		}
+
+		return nil
+	},
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bborbe/git-version-bumper/pkg/bumper"
+	"github.com/bborbe/git-version-bumper/pkg/lint"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+)
+
+var lintFlags = []cli.Flag{
+	cli.StringSliceFlag{Name: "allowed-type", Usage: "allowed Conventional Commits type, repeatable (default: feat, fix, perf, ...)", EnvVar: "ALLOWED_TYPE"},
+	cli.IntFlag{Name: "max-header-length", Value: lint.DefaultRules.MaxHeaderLength, Usage: "maximum commit header length, 0 disables the check", EnvVar: "MAX_HEADER_LENGTH"},
+	cli.StringFlag{Name: "scope-pattern", Usage: "regular expression the commit scope must match", EnvVar: "SCOPE_PATTERN"},
+	cli.BoolFlag{Name: "no-require-breaking-body", Usage: "don't require a body on breaking changes", EnvVar: "NO_REQUIRE_BREAKING_BODY"},
+}
+
+var lintCommand = cli.Command{
+	Name:      "lint",
+	Usage:     "validate commit messages in a rev-range against Conventional Commits rules",
+	ArgsUsage: "[<from>..<to>]",
+	Flags:     lintFlags,
+	Action: func(c *cli.Context) error {
+		repo, err := repoPath(c)
+		if err != nil {
+			return err
+		}
+
+		from, to := splitRevRange(c.Args().First())
+		commits, err := bumper.New(repo).Commits(from, to)
+		if err != nil {
+			return err
+		}
+
+		violations := lint.CheckAll(commits, rulesFromFlags(c))
+		for _, violation := range violations {
+			fmt.Println(violation)
+		}
+		if len(violations) > 0 {
+			return errors.Errorf("%d commit(s) failed lint", len(violations))
+		}
+		return nil
+	},
+}
+
+// splitRevRange splits a "from..to" rev-range at its first "..". An empty
+// from defaults to the latest semver tag and an empty to to HEAD. A bare
+// revision is treated as to, leaving from to default to the latest semver
+// tag.
+func splitRevRange(arg string) (from, to string) {
+	if idx := strings.Index(arg, ".."); idx >= 0 {
+		return arg[:idx], arg[idx+2:]
+	}
+	return "", arg
+}
+
+func rulesFromFlags(c *cli.Context) lint.Rules {
+	rules := lint.DefaultRules
+	if types := c.StringSlice("allowed-type"); len(types) > 0 {
+		rules.AllowedTypes = types
+	}
+	rules.MaxHeaderLength = c.Int("max-header-length")
+	if pattern := c.String("scope-pattern"); pattern != "" {
+		rules.ScopePattern = pattern
+	}
+	if c.Bool("no-require-breaking-body") {
+		rules.RequireBodyOnBreaking = false
+	}
+	return rules
+}
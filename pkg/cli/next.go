@@ -0,0 +1,39 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/bborbe/git-version-bumper/pkg/bumper"
+	"github.com/urfave/cli"
+)
+
+var nextCommand = cli.Command{
+	Name:  "next",
+	Usage: "print the next computed version without changing anything",
+	Flags: []cli.Flag{
+		cli.StringFlag{Name: "bump", Value: "auto", Usage: "auto|major|minor|patch", EnvVar: "BUMP"},
+		cli.StringFlag{Name: "from", Usage: "revision to scan from, defaults to the latest semver tag", EnvVar: "FROM"},
+		cli.StringFlag{Name: "to", Usage: "revision to scan to, defaults to HEAD", EnvVar: "TO"},
+		cli.StringFlag{Name: "pre-release", Usage: "pre-release identifier to append, e.g. rc.1", EnvVar: "PRE_RELEASE"},
+		cli.StringFlag{Name: "release-meta", Usage: "build metadata to append, e.g. build.42", EnvVar: "RELEASE_META"},
+	},
+	Action: func(c *cli.Context) error {
+		repo, err := repoPath(c)
+		if err != nil {
+			return err
+		}
+
+		next, err := bumper.New(repo).Next(c.String("bump"), c.String("from"), c.String("to"))
+		if err != nil {
+			return err
+		}
+
+		version := applyPreRelease(next.Version, c.String("pre-release"), c.String("release-meta"))
+		fmt.Println(version.String())
+		return nil
+	},
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/bborbe/git-version-bumper/conventional"
+	"github.com/bborbe/git-version-bumper/pkg/bumper"
+	"github.com/bborbe/git-version-bumper/pkg/forge"
+	"github.com/bborbe/git-version-bumper/pkg/sign"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli"
+	"golang.org/x/crypto/openpgp"
+)
+
+var releaseCommand = cli.Command{
+	Name:  "release",
+	Usage: "compute the next version, update the changelog, commit and tag",
+	Flags: append([]cli.Flag{
+		cli.StringFlag{Name: "git-author-name", Usage: "Author Name", EnvVar: "GIT_AUTHOR_NAME"},
+		cli.StringFlag{Name: "git-author-email", Usage: "Author Email", EnvVar: "GIT_AUTHOR_EMAIL"},
+		cli.StringFlag{Name: "message", Usage: "Message used for commit and changelog", EnvVar: "MESSAGE"},
+		cli.StringFlag{Name: "version", Usage: "Version used for commit and changelog, leave empty to infer it", EnvVar: "VERSION"},
+		cli.StringFlag{Name: "bump", Value: "auto", Usage: "auto|major|minor|patch", EnvVar: "BUMP"},
+		cli.StringFlag{Name: "from", Usage: "revision to scan from, defaults to the latest semver tag", EnvVar: "FROM"},
+		cli.StringFlag{Name: "to", Usage: "revision to scan to, defaults to HEAD", EnvVar: "TO"},
+		cli.StringFlag{Name: "changelog", Usage: "path to the changelog file, relative to repo", EnvVar: "CHANGELOG"},
+		cli.StringFlag{Name: "format", Value: "md", Usage: "md|json, ignored when --template-file is set; json has no changelog file to commit and requires --template-file", EnvVar: "FORMAT"},
+		cli.StringFlag{Name: "template-file", Usage: "path to a custom text/template changelog template", EnvVar: "TEMPLATE_FILE"},
+		cli.StringFlag{Name: "pre-release", Usage: "pre-release identifier to append, e.g. rc.1", EnvVar: "PRE_RELEASE"},
+		cli.StringFlag{Name: "release-meta", Usage: "build metadata to append, e.g. build.42", EnvVar: "RELEASE_META"},
+		cli.BoolFlag{Name: "sign", Usage: "GPG-sign the release commit and tag", EnvVar: "SIGN"},
+		cli.StringFlag{Name: "signing-key", Usage: "path to an armored GPG private key, required with --sign", EnvVar: "SIGNING_KEY"},
+		cli.BoolFlag{Name: "push", Usage: "push the release commit and tag", EnvVar: "PUSH"},
+		cli.StringFlag{Name: "remote", Value: "origin", Usage: "remote to push to", EnvVar: "REMOTE"},
+		cli.StringFlag{Name: "publish", Value: "none", Usage: "none|github|gitea|gitlab, publish release notes after tagging", EnvVar: "PUBLISH"},
+		cli.StringFlag{Name: "token-env", Value: "GITHUB_TOKEN", Usage: "environment variable holding the push/publish token", EnvVar: "TOKEN_ENV"},
+		cli.StringFlag{Name: "repo-slug", Usage: "owner/repo on the forge, required with --publish", EnvVar: "REPO_SLUG"},
+	}, lintFlags...),
+	Action: func(c *cli.Context) error {
+		repo, err := repoPath(c)
+		if err != nil {
+			return err
+		}
+		cfg, err := loadConfig(c, repo)
+		if err != nil {
+			return err
+		}
+
+		authorName := firstNonEmpty(c.String("git-author-name"), cfg.AuthorName)
+		authorEmail := firstNonEmpty(c.String("git-author-email"), cfg.AuthorEmail)
+		message := c.String("message")
+		if authorName == "" {
+			return errors.New("AuthorName missing")
+		}
+		if authorEmail == "" {
+			return errors.New("AuthorEmail missing")
+		}
+		if message == "" {
+			return errors.New("Message missing")
+		}
+
+		var signKey *openpgp.Entity
+		if c.Bool("sign") {
+			signingKey := c.String("signing-key")
+			if signingKey == "" {
+				return errors.New("signing-key missing")
+			}
+			signKey, err = sign.LoadKey(signingKey)
+			if err != nil {
+				return err
+			}
+		}
+
+		b := bumper.New(repo)
+
+		var version, previous bumper.Version
+		var commits []conventional.Commit
+		if v := c.String("version"); v != "" {
+			parsed, err := bumper.ParseVersion(v)
+			if err != nil {
+				return err
+			}
+			version = *parsed
+			previous, err = b.LatestVersion()
+			if err != nil {
+				return err
+			}
+			commits, err = b.Commits(c.String("from"), c.String("to"))
+			if err != nil {
+				return err
+			}
+		} else {
+			next, err := b.Next(c.String("bump"), c.String("from"), c.String("to"))
+			if err != nil {
+				return err
+			}
+			version = next.Version
+			previous = next.PreviousVersion
+			commits = next.Commits
+		}
+		version = applyPreRelease(version, c.String("pre-release"), c.String("release-meta"))
+
+		if c.String("publish") != "none" && c.String("repo-slug") == "" {
+			return errors.New("repo-slug missing")
+		}
+		token := os.Getenv(c.String("token-env"))
+		publisher, err := forge.New(c.String("publish"), token)
+		if err != nil {
+			return err
+		}
+
+		changelogPath := firstNonEmpty(c.String("changelog"), cfg.ChangelogPath)
+		return b.Release(context.Background(), version, previous, commits, bumper.ReleaseOptions{
+			ChangelogOptions: bumper.ChangelogOptions{
+				Format:       c.String("format"),
+				TemplateFile: firstNonEmpty(c.String("template-file"), cfg.Template),
+				Message:      message,
+			},
+			ChangelogPath: filepath.Join(repo, changelogPath),
+			AuthorName:    authorName,
+			AuthorEmail:   authorEmail,
+			LintRules:     rulesFromFlags(c),
+			SignKey:       signKey,
+			Push:          c.Bool("push"),
+			Remote:        c.String("remote"),
+			Token:         token,
+			Publisher:     publisher,
+			RepoSlug:      c.String("repo-slug"),
+		})
+	},
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package config holds the git-version-bumper configuration shared by the
+// init, next, changelog and release commands. Values are loaded from a YAML
+// file at the repository root and fall back to flags or environment
+// variables set by the caller.
+package config
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// Filename is the default name of the config file at the repository root.
+const Filename = ".gitversionbumper.yaml"
+
+// Config holds settings shared across subcommands.
+type Config struct {
+	AuthorName    string `yaml:"authorName,omitempty"`
+	AuthorEmail   string `yaml:"authorEmail,omitempty"`
+	ChangelogPath string `yaml:"changelogPath,omitempty"`
+	Template      string `yaml:"template,omitempty"`
+}
+
+// Load reads the config file at path. A missing file is not an error; a
+// zero Config is returned so callers can fall back to flags or env vars.
+func Load(path string) (*Config, error) {
+	content, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "read config failed")
+	}
+	config := &Config{}
+	if err := yaml.Unmarshal(content, config); err != nil {
+		return nil, errors.Wrap(err, "parse config failed")
+	}
+	return config, nil
+}
+
+// WithDefaults returns a copy of c with empty fields filled in with the
+// tool's defaults.
+func (c Config) WithDefaults() Config {
+	if c.ChangelogPath == "" {
+		c.ChangelogPath = "CHANGELOG.md"
+	}
+	return c
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package forge publishes release notes to a forge's REST API once a tag
+// has been created and pushed.
+package forge
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// ReleasePublisher publishes body as the release notes for version on the
+// repository identified by repoSlug, e.g. "bborbe/git-version-bumper".
+type ReleasePublisher interface {
+	Publish(ctx context.Context, repoSlug, version, body string) error
+}
+
+// New returns the ReleasePublisher for name (github|gitea|gitlab|none),
+// authenticating with token. "none" (and "") return a nil ReleasePublisher,
+// so callers must check for nil before publishing, the same way
+// Bumper.Release does.
+func New(name, token string) (ReleasePublisher, error) {
+	switch name {
+	case "", "none":
+		return nil, nil
+	case "github":
+		return &githubPublisher{token: token, baseURL: githubAPI}, nil
+	case "gitea":
+		return &giteaPublisher{token: token, baseURL: giteaAPI}, nil
+	case "gitlab":
+		return &gitlabPublisher{token: token, baseURL: gitlabAPI}, nil
+	default:
+		return nil, errors.Errorf("unknown publish target %q", name)
+	}
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package forge
+
+import "testing"
+
+func TestNew(t *testing.T) {
+	for _, name := range []string{"", "none"} {
+		t.Run("name="+name, func(t *testing.T) {
+			publisher, err := New(name, "token")
+			if err != nil {
+				t.Fatalf("New(%q, ...) unexpected error: %v", name, err)
+			}
+			if publisher != nil {
+				t.Errorf("New(%q, ...) = %v, want nil so callers can gate on it", name, publisher)
+			}
+		})
+	}
+
+	for _, name := range []string{"github", "gitea", "gitlab"} {
+		t.Run("name="+name, func(t *testing.T) {
+			publisher, err := New(name, "token")
+			if err != nil {
+				t.Fatalf("New(%q, ...) unexpected error: %v", name, err)
+			}
+			if publisher == nil {
+				t.Errorf("New(%q, ...) = nil, want a publisher", name)
+			}
+		})
+	}
+
+	if _, err := New("bitbucket", "token"); err == nil {
+		t.Error("New(\"bitbucket\", ...) expected error, got none")
+	}
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/pkg/errors"
+)
+
+// giteaAPI is the hosted gitea.com instance. Self-hosted instances are not
+// yet configurable through a flag.
+const giteaAPI = "https://gitea.com/api/v1"
+
+type giteaPublisher struct {
+	token   string
+	baseURL string
+}
+
+func (p *giteaPublisher) Publish(ctx context.Context, repoSlug, version, body string) error {
+	payload, err := json.Marshal(map[string]string{
+		"tag_name": version,
+		"name":     version,
+		"body":     body,
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal release payload failed")
+	}
+
+	url := fmt.Sprintf("%s/repos/%s/releases", p.baseURL, repoSlug)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "build request failed")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "token "+p.token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "publish release failed")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return errors.Errorf("publish release failed: unexpected status %s", resp.Status)
+	}
+	return nil
+}
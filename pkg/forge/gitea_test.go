@@ -0,0 +1,58 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGiteaPublisherPublish(t *testing.T) {
+	var gotPath, gotAuth string
+	var gotBody map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Fatalf("decode body failed: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	p := &giteaPublisher{token: "secret", baseURL: server.URL}
+	if err := p.Publish(context.Background(), "bborbe/git-version-bumper", "1.2.3", "release body"); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	if want := "/repos/bborbe/git-version-bumper/releases"; gotPath != want {
+		t.Errorf("path = %q, want %q", gotPath, want)
+	}
+	if want := "token secret"; gotAuth != want {
+		t.Errorf("Authorization = %q, want %q", gotAuth, want)
+	}
+	want := map[string]string{"tag_name": "1.2.3", "name": "1.2.3", "body": "release body"}
+	for k, v := range want {
+		if gotBody[k] != v {
+			t.Errorf("body[%q] = %q, want %q", k, gotBody[k], v)
+		}
+	}
+}
+
+func TestGiteaPublisherPublishError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	p := &giteaPublisher{token: "secret", baseURL: server.URL}
+	if err := p.Publish(context.Background(), "bborbe/git-version-bumper", "1.2.3", "body"); err == nil {
+		t.Fatal("Publish expected error on non-2xx response, got none")
+	}
+}
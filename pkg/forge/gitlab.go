@@ -0,0 +1,53 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+const gitlabAPI = "https://gitlab.com/api/v4"
+
+type gitlabPublisher struct {
+	token   string
+	baseURL string
+}
+
+func (p *gitlabPublisher) Publish(ctx context.Context, repoSlug, version, body string) error {
+	payload, err := json.Marshal(map[string]string{
+		"tag_name":    version,
+		"name":        version,
+		"description": body,
+	})
+	if err != nil {
+		return errors.Wrap(err, "marshal release payload failed")
+	}
+
+	endpoint := fmt.Sprintf("%s/projects/%s/releases", p.baseURL, url.PathEscape(repoSlug))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "build request failed")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("PRIVATE-TOKEN", p.token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "publish release failed")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return errors.Errorf("publish release failed: unexpected status %s", resp.Status)
+	}
+	return nil
+}
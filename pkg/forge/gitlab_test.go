@@ -0,0 +1,62 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGitlabPublisherPublish(t *testing.T) {
+	var gotPath, gotPrivateToken string
+	var gotBody map[string]string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.EscapedPath()
+		gotPrivateToken = r.Header.Get("PRIVATE-TOKEN")
+		if err := json.NewDecoder(r.Body).Decode(&gotBody); err != nil {
+			t.Fatalf("decode body failed: %v", err)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	p := &gitlabPublisher{token: "secret", baseURL: server.URL}
+	if err := p.Publish(context.Background(), "bborbe/git-version-bumper", "1.2.3", "release body"); err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+
+	if want := "/projects/bborbe%2Fgit-version-bumper/releases"; gotPath != want {
+		t.Errorf("path = %q, want %q", gotPath, want)
+	}
+	if want := "secret"; gotPrivateToken != want {
+		t.Errorf("PRIVATE-TOKEN = %q, want %q", gotPrivateToken, want)
+	}
+	// GitLab's Releases API uses "description", not "body" like GitHub/Gitea.
+	want := map[string]string{"tag_name": "1.2.3", "name": "1.2.3", "description": "release body"}
+	for k, v := range want {
+		if gotBody[k] != v {
+			t.Errorf("body[%q] = %q, want %q", k, gotBody[k], v)
+		}
+	}
+	if _, ok := gotBody["body"]; ok {
+		t.Errorf("body unexpectedly contains a %q field", "body")
+	}
+}
+
+func TestGitlabPublisherPublishError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	p := &gitlabPublisher{token: "secret", baseURL: server.URL}
+	if err := p.Publish(context.Background(), "bborbe/git-version-bumper", "1.2.3", "body"); err == nil {
+		t.Fatal("Publish expected error on non-2xx response, got none")
+	}
+}
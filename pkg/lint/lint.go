@@ -0,0 +1,93 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package lint validates commit messages against a configurable
+// Conventional Commits rule set. It is exposed as the standalone
+// `git-version-bumper lint` command and also runs as a pre-flight check
+// before a release is tagged.
+package lint
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/bborbe/git-version-bumper/conventional"
+	"github.com/pkg/errors"
+)
+
+// Rules configures which commit messages Check accepts. The zero value
+// disables every check.
+type Rules struct {
+	// RequireConventionalFormat rejects commits whose header does not parse
+	// as Conventional Commits at all, i.e. conventional.Commit.Type == "".
+	RequireConventionalFormat bool
+	// AllowedTypes restricts the Conventional Commits type, e.g. "feat" or
+	// "fix". Empty means any type is accepted.
+	AllowedTypes []string
+	// MaxHeaderLength limits the length of the commit header. Zero
+	// disables the check.
+	MaxHeaderLength int
+	// ScopePattern, if set, is a regular expression the commit scope must
+	// match. Commits without a scope are exempt.
+	ScopePattern string
+	// RequireBodyOnBreaking requires a non-empty body on breaking changes,
+	// where the rationale for the break is expected to live.
+	RequireBodyOnBreaking bool
+}
+
+// DefaultRules are applied by the release pre-flight check and the lint
+// command unless overridden by flags.
+var DefaultRules = Rules{
+	RequireConventionalFormat: true,
+	AllowedTypes:              []string{"feat", "fix", "perf", "refactor", "docs", "style", "test", "build", "ci", "chore", "revert"},
+	MaxHeaderLength:           100,
+	RequireBodyOnBreaking:     true,
+}
+
+// Check validates a single commit against rules, returning nil if it
+// passes.
+func Check(commit conventional.Commit, rules Rules) error {
+	if rules.RequireConventionalFormat && commit.Type == "" {
+		return errors.Errorf("header %q does not follow the Conventional Commits format", commit.Header)
+	}
+	if len(rules.AllowedTypes) > 0 && !contains(rules.AllowedTypes, commit.Type) {
+		return errors.Errorf("type %q is not one of %v", commit.Type, rules.AllowedTypes)
+	}
+	if rules.MaxHeaderLength > 0 && len(commit.Header) > rules.MaxHeaderLength {
+		return errors.Errorf("header exceeds %d characters", rules.MaxHeaderLength)
+	}
+	if commit.Scope != "" && rules.ScopePattern != "" {
+		matched, err := regexp.MatchString(rules.ScopePattern, commit.Scope)
+		if err != nil {
+			return errors.Wrap(err, "invalid scope pattern")
+		}
+		if !matched {
+			return errors.Errorf("scope %q does not match pattern %q", commit.Scope, rules.ScopePattern)
+		}
+	}
+	if commit.Breaking && rules.RequireBodyOnBreaking && strings.TrimSpace(commit.Body) == "" {
+		return errors.New("breaking change requires a commit body explaining it")
+	}
+	return nil
+}
+
+// CheckAll lints every commit, returning one error per violation found.
+func CheckAll(commits []conventional.Commit, rules Rules) []error {
+	var violations []error
+	for _, commit := range commits {
+		if err := Check(commit, rules); err != nil {
+			violations = append(violations, errors.Wrapf(err, "commit %s", commit.Hash))
+		}
+	}
+	return violations
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
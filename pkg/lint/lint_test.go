@@ -0,0 +1,125 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lint
+
+import (
+	"testing"
+
+	"github.com/bborbe/git-version-bumper/conventional"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		commit  conventional.Commit
+		rules   Rules
+		wantErr bool
+	}{
+		{
+			name:   "zero value accepts anything",
+			commit: conventional.Commit{Header: "whatever this is, no type at all"},
+			rules:  Rules{},
+		},
+		{
+			name:    "RequireConventionalFormat rejects commits without a type",
+			commit:  conventional.Commit{Header: "whatever"},
+			rules:   Rules{RequireConventionalFormat: true},
+			wantErr: true,
+		},
+		{
+			name:   "RequireConventionalFormat accepts a parsed type",
+			commit: conventional.Commit{Header: "feat: add widgets", Type: "feat"},
+			rules:  Rules{RequireConventionalFormat: true},
+		},
+		{
+			name:    "AllowedTypes rejects a type not in the list",
+			commit:  conventional.Commit{Type: "wip"},
+			rules:   Rules{AllowedTypes: []string{"feat", "fix"}},
+			wantErr: true,
+		},
+		{
+			name:   "AllowedTypes accepts a type in the list",
+			commit: conventional.Commit{Type: "fix"},
+			rules:  Rules{AllowedTypes: []string{"feat", "fix"}},
+		},
+		{
+			name:    "MaxHeaderLength rejects a header that is too long",
+			commit:  conventional.Commit{Header: "feat: this header is much too long to pass"},
+			rules:   Rules{MaxHeaderLength: 10},
+			wantErr: true,
+		},
+		{
+			name:   "MaxHeaderLength zero disables the check",
+			commit: conventional.Commit{Header: "feat: this header is much too long to pass"},
+			rules:  Rules{MaxHeaderLength: 0},
+		},
+		{
+			name:    "ScopePattern rejects a scope that does not match",
+			commit:  conventional.Commit{Scope: "ui"},
+			rules:   Rules{ScopePattern: "^api$"},
+			wantErr: true,
+		},
+		{
+			name:   "ScopePattern accepts a matching scope",
+			commit: conventional.Commit{Scope: "api"},
+			rules:  Rules{ScopePattern: "^api$"},
+		},
+		{
+			name:   "ScopePattern exempts commits without a scope",
+			commit: conventional.Commit{Scope: ""},
+			rules:  Rules{ScopePattern: "^api$"},
+		},
+		{
+			name:    "RequireBodyOnBreaking rejects an empty body",
+			commit:  conventional.Commit{Breaking: true, Body: "   "},
+			rules:   Rules{RequireBodyOnBreaking: true},
+			wantErr: true,
+		},
+		{
+			name:   "RequireBodyOnBreaking accepts a non-empty body",
+			commit: conventional.Commit{Breaking: true, Body: "explains the break"},
+			rules:  Rules{RequireBodyOnBreaking: true},
+		},
+		{
+			name:   "RequireBodyOnBreaking ignores non-breaking commits",
+			commit: conventional.Commit{Breaking: false, Body: ""},
+			rules:  Rules{RequireBodyOnBreaking: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.commit, tt.rules)
+			if tt.wantErr && err == nil {
+				t.Fatalf("Check() expected error, got none")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("Check() unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestCheckAll(t *testing.T) {
+	commits := []conventional.Commit{
+		{Hash: "aaa", Type: "feat"},
+		{Hash: "bbb", Type: "wip"},
+		{Hash: "ccc", Type: "fix"},
+		{Hash: "ddd", Type: "wip"},
+	}
+	violations := CheckAll(commits, Rules{AllowedTypes: []string{"feat", "fix"}})
+	if got, want := len(violations), 2; got != want {
+		t.Fatalf("CheckAll() returned %d violations, want %d: %v", got, want, violations)
+	}
+}
+
+func TestDefaultRules(t *testing.T) {
+	if err := Check(conventional.Commit{Header: "not conventional at all"}, DefaultRules); err == nil {
+		t.Error("DefaultRules expected to reject a non-Conventional-Commits header")
+	}
+	if err := Check(conventional.Commit{Header: "feat: add widgets", Type: "feat"}, DefaultRules); err != nil {
+		t.Errorf("DefaultRules unexpected error on a well-formed commit: %v", err)
+	}
+}
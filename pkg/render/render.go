@@ -0,0 +1,116 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package render turns a changelog release into text using pluggable
+// text/template templates, similar to git-sv's template helpers. Callers
+// supply either a built-in --format (md|json) or a custom --template-file.
+package render
+
+import (
+	"bytes"
+	"embed"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/bborbe/git-version-bumper/conventional"
+	"github.com/pkg/errors"
+)
+
+//go:embed templates/*.tpl
+var defaultTemplates embed.FS
+
+// Section groups the commits belonging to one changelog heading, e.g.
+// "Features" or "BREAKING CHANGES".
+type Section struct {
+	Name    string
+	Title   string
+	Commits []conventional.Commit
+}
+
+// Data is the value every template is executed with.
+type Data struct {
+	Version         string
+	PreviousVersion string
+	Date            time.Time
+	Sections        []Section
+	Commits         []conventional.Commit
+	// Message is used by the default templates when Sections is empty.
+	Message string
+}
+
+// Renderer turns Data into the changelog text for a single release.
+type Renderer interface {
+	Render(data Data) (string, error)
+}
+
+// New returns a Renderer for format (md|json). If templateFile is set, it
+// takes precedence over format and is parsed from disk instead of using the
+// embedded default.
+func New(format, templateFile string) (Renderer, error) {
+	funcMap := template.FuncMap{
+		"timefmt":    func(t time.Time, layout string) string { return t.Format(layout) },
+		"getsection": getSection,
+		"upper":      strings.ToUpper,
+		"trim":       strings.TrimSpace,
+	}
+
+	if templateFile != "" {
+		name := filepath.Base(templateFile)
+		tpl, err := template.New(name).Funcs(funcMap).ParseFiles(templateFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse template file failed")
+		}
+		return &templateRenderer{tpl: tpl}, nil
+	}
+
+	name, err := defaultTemplateName(format)
+	if err != nil {
+		return nil, err
+	}
+	content, err := defaultTemplates.ReadFile(name)
+	if err != nil {
+		return nil, errors.Wrap(err, "read default template failed")
+	}
+	tpl, err := template.New(filepath.Base(name)).Funcs(funcMap).Parse(string(content))
+	if err != nil {
+		return nil, errors.Wrap(err, "parse default template failed")
+	}
+	return &templateRenderer{tpl: tpl}, nil
+}
+
+func defaultTemplateName(format string) (string, error) {
+	switch format {
+	case "", "md":
+		return "templates/default.md.tpl", nil
+	case "json":
+		return "templates/default.json.tpl", nil
+	default:
+		return "", errors.Errorf("unknown format %q", format)
+	}
+}
+
+// getSection looks up a section by name, returning nil if it is absent so
+// templates can use {{with getsection .Sections "feat"}}.
+func getSection(sections []Section, name string) *Section {
+	for i := range sections {
+		if sections[i].Name == name {
+			return &sections[i]
+		}
+	}
+	return nil
+}
+
+type templateRenderer struct {
+	tpl *template.Template
+}
+
+func (r *templateRenderer) Render(data Data) (string, error) {
+	var buf bytes.Buffer
+	if err := r.tpl.Execute(&buf, data); err != nil {
+		return "", errors.Wrap(err, "execute template failed")
+	}
+	return buf.String(), nil
+}
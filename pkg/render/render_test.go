@@ -0,0 +1,142 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package render
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/bborbe/git-version-bumper/conventional"
+)
+
+func TestRenderDefaultTemplates(t *testing.T) {
+	date := time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		format string
+		data   Data
+		want   []string
+	}{
+		{
+			name:   "md with sections",
+			format: "md",
+			data: Data{
+				Version:         "1.1.0",
+				PreviousVersion: "1.0.0",
+				Date:            date,
+				Sections: []Section{
+					{
+						Name:  "feat",
+						Title: "Features",
+						Commits: []conventional.Commit{
+							{Scope: "api", Subject: "add widgets"},
+							{Subject: "add gadgets"},
+						},
+					},
+				},
+			},
+			want: []string{"## 1.1.0", "### Features", "**api:** add widgets", "- add gadgets"},
+		},
+		{
+			name:   "md without sections falls back to message",
+			format: "md",
+			data:   Data{Version: "1.0.1", Message: "release 1.0.1"},
+			want:   []string{"## 1.0.1", "- release 1.0.1"},
+		},
+		{
+			name:   "json with sections",
+			format: "json",
+			data: Data{
+				Version:         "1.1.0",
+				PreviousVersion: "1.0.0",
+				Date:            date,
+				Sections: []Section{
+					{
+						Name:  "feat",
+						Title: "Features",
+						Commits: []conventional.Commit{
+							{Scope: "api", Subject: "add widgets"},
+						},
+					},
+				},
+			},
+			want: []string{
+				`"version": "1.1.0"`,
+				`"previousVersion": "1.0.0"`,
+				`"date": "2026-01-02"`,
+				`"name": "Features"`,
+				`"scope": "api", "subject": "add widgets"`,
+			},
+		},
+		{
+			name:   "json without sections",
+			format: "json",
+			data:   Data{Version: "1.0.1", PreviousVersion: "1.0.0", Date: date},
+			want:   []string{`"version": "1.0.1"`, `"sections": [` + "\n  ]"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer, err := New(tt.format, "")
+			if err != nil {
+				t.Fatalf("New(%q, \"\") failed: %v", tt.format, err)
+			}
+			got, err := renderer.Render(tt.data)
+			if err != nil {
+				t.Fatalf("Render failed: %v", err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("Render() = %q, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderUnknownFormat(t *testing.T) {
+	if _, err := New("yaml", ""); err == nil {
+		t.Fatal("New(\"yaml\", \"\") expected error, got none")
+	}
+}
+
+func TestRenderTemplateFileOverride(t *testing.T) {
+	dir, err := ioutil.TempDir("", "render-test")
+	if err != nil {
+		t.Fatalf("TempDir failed: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	templateFile := filepath.Join(dir, "custom.tpl")
+	content := `{{upper .Version}} ({{timefmt .Date "2006"}}) {{with getsection .Sections "feat"}}{{trim .Title}}{{end}}`
+	if err := ioutil.WriteFile(templateFile, []byte(content), 0600); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+
+	renderer, err := New("md", templateFile)
+	if err != nil {
+		t.Fatalf("New with template file failed: %v", err)
+	}
+
+	got, err := renderer.Render(Data{
+		Version: "1.2.3",
+		Date:    time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC),
+		Sections: []Section{
+			{Name: "feat", Title: " Features "},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if want := "1.2.3 (2026) Features"; got != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2018 Benjamin Borbe All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package sign loads the GPG private key used to sign release commits and
+// tags.
+package sign
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/openpgp"
+)
+
+// LoadKey reads an armored GPG private key from path and returns the first
+// entity in it. It fails clearly if the key file is absent, or if that first
+// entity has no private key or is still passphrase-locked, since go-git has
+// no way to prompt for one; later entities in the file are not considered.
+func LoadKey(path string) (*openpgp.Entity, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "open signing key failed")
+	}
+	defer f.Close()
+
+	entityList, err := openpgp.ReadArmoredKeyRing(f)
+	if err != nil {
+		return nil, errors.Wrap(err, "read signing key failed")
+	}
+	if len(entityList) == 0 {
+		return nil, errors.New("signing key file contains no keys")
+	}
+
+	entity := entityList[0]
+	if entity.PrivateKey == nil {
+		return nil, errors.New("signing key has no private key")
+	}
+	if entity.PrivateKey.Encrypted {
+		return nil, errors.New("signing key is locked, decrypt it before use")
+	}
+	return entity, nil
+}